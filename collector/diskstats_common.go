@@ -0,0 +1,78 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !nodiskstats
+
+package collector
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const diskSubsystem = "disk"
+
+var diskLabelNames = []string{"device"}
+
+// diskstatsDescs holds the disk I/O metric descriptors whose name, help text
+// and labels are identical across every diskstats collector implementation.
+// Platforms that expose additional counters (e.g. Darwin's sector and retry
+// counts) declare those separately in their own file.
+type diskstatsDescs struct {
+	ReadsCompleted  typedDesc
+	ReadBytes       typedDesc
+	WritesCompleted typedDesc
+	WrittenBytes    typedDesc
+	ReadTime        typedDesc
+	WriteTime       typedDesc
+	IOTime          typedDesc
+}
+
+// newDiskstatsDescs returns the descriptors shared by every platform's
+// diskstats collector.
+func newDiskstatsDescs() diskstatsDescs {
+	return diskstatsDescs{
+		ReadsCompleted: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, diskSubsystem, "reads_completed_total"),
+			"The total number of reads completed successfully.",
+			diskLabelNames, constLabels,
+		), prometheus.CounterValue},
+		ReadBytes: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, diskSubsystem, "read_bytes_total"),
+			"The total number of bytes read successfully.",
+			diskLabelNames, constLabels,
+		), prometheus.CounterValue},
+		WritesCompleted: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, diskSubsystem, "writes_completed_total"),
+			"The total number of writes completed successfully.",
+			diskLabelNames, constLabels,
+		), prometheus.CounterValue},
+		WrittenBytes: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, diskSubsystem, "written_bytes_total"),
+			"The total number of bytes written successfully.",
+			diskLabelNames, constLabels,
+		), prometheus.CounterValue},
+		ReadTime: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, diskSubsystem, "read_time_seconds_total"),
+			"The total number of seconds spent by all reads.",
+			diskLabelNames, constLabels,
+		), prometheus.CounterValue},
+		WriteTime: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, diskSubsystem, "write_time_seconds_total"),
+			"This is the total number of seconds spent by all writes.",
+			diskLabelNames, constLabels,
+		), prometheus.CounterValue},
+		IOTime: typedDesc{prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, diskSubsystem, "io_time_seconds_total"),
+			"Total seconds spent doing I/Os.",
+			diskLabelNames, constLabels,
+		), prometheus.CounterValue},
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !nodiskstats
+
+package collector
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestNewDiskstatsDescs makes sure the descriptors shared across platforms
+// keep the fully qualified names and label set that the Linux and Darwin
+// collectors have always exposed, so an OpenBSD (or any future) collector
+// built on top of them stays schema-compatible.
+func TestNewDiskstatsDescs(t *testing.T) {
+	wantFQNames := map[string]string{
+		"ReadsCompleted":  "node_disk_reads_completed_total",
+		"ReadBytes":       "node_disk_read_bytes_total",
+		"WritesCompleted": "node_disk_writes_completed_total",
+		"WrittenBytes":    "node_disk_written_bytes_total",
+		"ReadTime":        "node_disk_read_time_seconds_total",
+		"WriteTime":       "node_disk_write_time_seconds_total",
+		"IOTime":          "node_disk_io_time_seconds_total",
+	}
+
+	descs := newDiskstatsDescs()
+	got := map[string]typedDesc{
+		"ReadsCompleted":  descs.ReadsCompleted,
+		"ReadBytes":       descs.ReadBytes,
+		"WritesCompleted": descs.WritesCompleted,
+		"WrittenBytes":    descs.WrittenBytes,
+		"ReadTime":        descs.ReadTime,
+		"WriteTime":       descs.WriteTime,
+		"IOTime":          descs.IOTime,
+	}
+
+	for field, fqName := range wantFQNames {
+		s := got[field].desc.String()
+		if !strings.Contains(s, fqName) {
+			t.Errorf("descs.%s: expected fqName %q, got desc %s", field, fqName, s)
+		}
+		if !strings.Contains(s, `"device"`) {
+			t.Errorf("descs.%s: expected %q label, got desc %s", field, "device", s)
+		}
+	}
+}
@@ -39,54 +39,12 @@ func init() {
 
 // NewDiskstatsCollector returns a new Collector exposing disk device stats.
 func NewDiskstatsCollector(logger log.Logger) (Collector, error) {
-	readsCompletedDesc := prometheus.NewDesc(
-		prometheus.BuildFQName(namespace, diskSubsystem, "reads_completed_total"),
-		"The total number of reads completed successfully.",
-		diskLabelNames, constLabels,
-	)
+	descs := newDiskstatsDescs()
 
-	readBytesDesc := prometheus.NewDesc(
-		prometheus.BuildFQName(namespace, diskSubsystem, "read_bytes_total"),
-		"The total number of bytes read successfully.",
-		diskLabelNames, constLabels,
-	)
-
-	writesCompletedDesc := prometheus.NewDesc(
-		prometheus.BuildFQName(namespace, diskSubsystem, "writes_completed_total"),
-		"The total number of writes completed successfully.",
-		diskLabelNames, constLabels,
-	)
-
-	writtenBytesDesc := prometheus.NewDesc(
-		prometheus.BuildFQName(namespace, diskSubsystem, "written_bytes_total"),
-		"The total number of bytes written successfully.",
-		diskLabelNames, constLabels,
-	)
-
-	ioTimeSecondsDesc := prometheus.NewDesc(
-		prometheus.BuildFQName(namespace, diskSubsystem, "io_time_seconds_total"),
-		"Total seconds spent doing I/Os.",
-		diskLabelNames, constLabels,
-	)
-
-	readTimeSecondsDesc := prometheus.NewDesc(
-		prometheus.BuildFQName(namespace, diskSubsystem, "read_time_seconds_total"),
-		"The total number of seconds spent by all reads.",
-		diskLabelNames, constLabels,
-	)
-
-	writeTimeSecondsDesc := prometheus.NewDesc(
-		prometheus.BuildFQName(namespace, diskSubsystem, "write_time_seconds_total"),
-		"This is the total number of seconds spent by all writes.",
-		diskLabelNames, constLabels,
-	)
 	return &diskstatsCollector{
 		descs: []typedDescFunc{
 			{
-				typedDesc: typedDesc{
-					desc:      readsCompletedDesc,
-					valueType: prometheus.CounterValue,
-				},
+				typedDesc: descs.ReadsCompleted,
 				value: func(stat *iostat.DriveStats) float64 {
 					return float64(stat.NumRead)
 				},
@@ -106,19 +64,13 @@ func NewDiskstatsCollector(logger log.Logger) (Collector, error) {
 				},
 			},
 			{
-				typedDesc: typedDesc{
-					desc:      readTimeSecondsDesc,
-					valueType: prometheus.CounterValue,
-				},
+				typedDesc: descs.ReadTime,
 				value: func(stat *iostat.DriveStats) float64 {
 					return stat.TotalReadTime.Seconds()
 				},
 			},
 			{
-				typedDesc: typedDesc{
-					desc:      writesCompletedDesc,
-					valueType: prometheus.CounterValue,
-				},
+				typedDesc: descs.WritesCompleted,
 				value: func(stat *iostat.DriveStats) float64 {
 					return float64(stat.NumWrite)
 				},
@@ -138,28 +90,25 @@ func NewDiskstatsCollector(logger log.Logger) (Collector, error) {
 				},
 			},
 			{
-				typedDesc: typedDesc{
-					desc:      writeTimeSecondsDesc,
-					valueType: prometheus.CounterValue,
-				},
+				typedDesc: descs.WriteTime,
 				value: func(stat *iostat.DriveStats) float64 {
 					return stat.TotalWriteTime.Seconds()
 				},
 			},
 			{
-				typedDesc: typedDesc{
-					desc:      readBytesDesc,
-					valueType: prometheus.CounterValue,
+				typedDesc: descs.IOTime,
+				value: func(stat *iostat.DriveStats) float64 {
+					return (stat.TotalReadTime + stat.TotalWriteTime).Seconds()
 				},
+			},
+			{
+				typedDesc: descs.ReadBytes,
 				value: func(stat *iostat.DriveStats) float64 {
 					return float64(stat.BytesRead)
 				},
 			},
 			{
-				typedDesc: typedDesc{
-					desc:      writtenBytesDesc,
-					valueType: prometheus.CounterValue,
-				},
+				typedDesc: descs.WrittenBytes,
 				value: func(stat *iostat.DriveStats) float64 {
 					return float64(stat.BytesWritten)
 				},
@@ -0,0 +1,111 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build openbsd
+
+package collector
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/unix"
+)
+
+const diskstatsNameSize = 16
+
+// diskstats mirrors OpenBSD's struct diskstats (sys/disk.h), as returned in
+// an array by the hw.diskstats sysctl.
+type diskstats struct {
+	Name       [diskstatsNameSize]byte
+	Busy       int32
+	_          [4]byte // padding to keep the following fields 8-byte aligned
+	ReadXfers  uint64
+	WriteXfers uint64
+	Seek       uint64
+	ReadBytes  uint64
+	WriteBytes uint64
+	AttachTime unix.Timeval
+	Timestamp  unix.Timeval
+	Time       unix.Timeval
+}
+
+type diskstatsCollector struct {
+	reads        typedDesc
+	writes       typedDesc
+	readBytes    typedDesc
+	writtenBytes typedDesc
+	ioTime       typedDesc
+	logger       log.Logger
+}
+
+func init() {
+	registerCollector("diskstats", defaultEnabled, NewDiskstatsCollector)
+}
+
+// NewDiskstatsCollector returns a new Collector exposing disk device stats.
+func NewDiskstatsCollector(logger log.Logger) (Collector, error) {
+	descs := newDiskstatsDescs()
+
+	return &diskstatsCollector{
+		reads:        descs.ReadsCompleted,
+		writes:       descs.WritesCompleted,
+		readBytes:    descs.ReadBytes,
+		writtenBytes: descs.WrittenBytes,
+		ioTime:       descs.IOTime,
+		logger:       logger,
+	}, nil
+}
+
+func (c *diskstatsCollector) Update(ch chan<- prometheus.Metric) error {
+	buf, err := unix.SysctlRaw("hw.diskstats")
+	if err != nil {
+		return fmt.Errorf("couldn't get hw.diskstats: %w", err)
+	}
+
+	entrySize := binary.Size(diskstats{})
+	if entrySize <= 0 || len(buf)%entrySize != 0 {
+		return fmt.Errorf("unexpected hw.diskstats size: %d bytes (entry size %d)", len(buf), entrySize)
+	}
+
+	for offset := 0; offset+entrySize <= len(buf); offset += entrySize {
+		var ds diskstats
+		reader := bytes.NewReader(buf[offset : offset+entrySize])
+		if err := binary.Read(reader, binary.LittleEndian, &ds); err != nil {
+			return fmt.Errorf("couldn't parse hw.diskstats entry: %w", err)
+		}
+
+		name := strings.TrimRight(string(ds.Name[:]), "\x00")
+		if name == "" {
+			continue
+		}
+
+		ch <- c.reads.mustNewConstMetric(float64(ds.ReadXfers), name)
+		ch <- c.writes.mustNewConstMetric(float64(ds.WriteXfers), name)
+		ch <- c.readBytes.mustNewConstMetric(float64(ds.ReadBytes), name)
+		ch <- c.writtenBytes.mustNewConstMetric(float64(ds.WriteBytes), name)
+		ch <- c.ioTime.mustNewConstMetric(timevalToSeconds(ds.Time), name)
+	}
+
+	return nil
+}
+
+// timevalToSeconds converts a unix.Timeval, as found in ds_time, into a
+// floating point number of seconds.
+func timevalToSeconds(tv unix.Timeval) float64 {
+	return float64(tv.Sec) + float64(tv.Usec)/1e6
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build openbsd
+
+package collector
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestTimevalToSeconds(t *testing.T) {
+	got := timevalToSeconds(unix.Timeval{Sec: 1, Usec: 500000})
+	if want := 1.5; math.Abs(got-want) > 1e-9 {
+		t.Errorf("timevalToSeconds(1s 500000us) = %v, want %v", got, want)
+	}
+}
+
+// TestDiskstatsEntryDecode builds a synthetic hw.diskstats entry with a known
+// ds_time and makes sure it round-trips through binary.Read the same way
+// Update decodes the real sysctl buffer, guarding against ds_time being
+// mistaken for a timespec (nanoseconds) instead of a timeval (microseconds).
+func TestDiskstatsEntryDecode(t *testing.T) {
+	want := diskstats{
+		Time: unix.Timeval{Sec: 1, Usec: 500000},
+	}
+	copy(want.Name[:], "sd0")
+
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, binary.LittleEndian, want); err != nil {
+		t.Fatalf("binary.Write: %v", err)
+	}
+
+	var got diskstats
+	if err := binary.Read(bytes.NewReader(buf.Bytes()), binary.LittleEndian, &got); err != nil {
+		t.Fatalf("binary.Read: %v", err)
+	}
+
+	if got.Time != want.Time {
+		t.Fatalf("decoded ds_time = %+v, want %+v", got.Time, want.Time)
+	}
+
+	if gotSeconds, want := timevalToSeconds(got.Time), 1.5; math.Abs(gotSeconds-want) > 1e-9 {
+		t.Errorf("node_disk_io_time_seconds_total = %v, want %v", gotSeconds, want)
+	}
+}
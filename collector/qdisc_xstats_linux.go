@@ -0,0 +1,118 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !noqdisc
+
+package collector
+
+import "encoding/binary"
+
+// Decoders for the kind-specific xstats payloads the kernel nests inside a
+// qdisc's TCA_STATS2/TCA_STATS_APP netlink attribute (struct layouts from
+// linux/pkt_sched.h). They are not wired into qdiscStatCollector yet: the
+// vendored github.com/ema/qdisc client parses the common tc_stats2 counters
+// (bytes/packets/drops/...) but does not currently surface the raw
+// TCA_STATS_APP bytes on QdiscInfo, so there's nothing for these decoders
+// to read in Update(). They're kept here, decoded and unit-tested against
+// the kernel ABI, so that wiring them in is a small follow-up once that
+// raw payload is available (either from a newer ema/qdisc or a lower-level
+// netlink client) rather than a from-scratch exercise.
+
+const htbXstatsSize = 20
+
+// htbXstats mirrors struct tc_htb_xstats.
+type htbXstats struct {
+	Lends   uint32
+	Borrows uint32
+	Giants  uint32
+	Tokens  int32
+	CTokens int32
+}
+
+// decodeHTBXstats decodes an HTB class/qdisc's raw TCA_STATS_APP payload.
+func decodeHTBXstats(b []byte) (htbXstats, bool) {
+	var x htbXstats
+	if len(b) < htbXstatsSize {
+		return x, false
+	}
+
+	x.Lends = binary.LittleEndian.Uint32(b[0:4])
+	x.Borrows = binary.LittleEndian.Uint32(b[4:8])
+	x.Giants = binary.LittleEndian.Uint32(b[8:12])
+	x.Tokens = int32(binary.LittleEndian.Uint32(b[12:16]))
+	x.CTokens = int32(binary.LittleEndian.Uint32(b[16:20]))
+	return x, true
+}
+
+const (
+	fqCodelXstatsQdiscType uint32 = 0
+	fqCodelXstatsClassType uint32 = 1
+
+	fqCodelXstatsSize = 28
+)
+
+// fqCodelQdiscXstats mirrors struct tc_fq_codel_qd_stats.
+type fqCodelQdiscXstats struct {
+	MaxPacket     uint32
+	DropOverlimit uint32
+	EcnMark       uint32
+	NewFlowCount  uint32
+	NewFlowsLen   uint32
+	OldFlowsLen   uint32
+}
+
+// fqCodelClassXstats mirrors struct tc_fq_codel_cl_stats.
+type fqCodelClassXstats struct {
+	Deficit   int32
+	LDelay    uint32
+	Count     uint32
+	LastCount uint32
+	Dropping  uint32
+	DropNext  int32
+}
+
+// decodeFqCodelQdiscXstats decodes an fq_codel qdisc's raw TCA_STATS_APP
+// payload. It returns false if the payload is too short or its embedded
+// type discriminator says it's actually class, not qdisc, stats.
+func decodeFqCodelQdiscXstats(b []byte) (fqCodelQdiscXstats, bool) {
+	var x fqCodelQdiscXstats
+	if len(b) < fqCodelXstatsSize || binary.LittleEndian.Uint32(b[0:4]) != fqCodelXstatsQdiscType {
+		return x, false
+	}
+
+	x.MaxPacket = binary.LittleEndian.Uint32(b[4:8])
+	x.DropOverlimit = binary.LittleEndian.Uint32(b[8:12])
+	x.EcnMark = binary.LittleEndian.Uint32(b[12:16])
+	x.NewFlowCount = binary.LittleEndian.Uint32(b[16:20])
+	x.NewFlowsLen = binary.LittleEndian.Uint32(b[20:24])
+	x.OldFlowsLen = binary.LittleEndian.Uint32(b[24:28])
+	return x, true
+}
+
+// decodeFqCodelClassXstats decodes an fq_codel class's raw TCA_STATS_APP
+// payload. It returns false if the payload is too short or its embedded
+// type discriminator says it's actually qdisc, not class, stats.
+func decodeFqCodelClassXstats(b []byte) (fqCodelClassXstats, bool) {
+	var x fqCodelClassXstats
+	if len(b) < fqCodelXstatsSize || binary.LittleEndian.Uint32(b[0:4]) != fqCodelXstatsClassType {
+		return x, false
+	}
+
+	x.Deficit = int32(binary.LittleEndian.Uint32(b[4:8]))
+	x.LDelay = binary.LittleEndian.Uint32(b[8:12])
+	x.Count = binary.LittleEndian.Uint32(b[12:16])
+	x.LastCount = binary.LittleEndian.Uint32(b[16:20])
+	x.Dropping = binary.LittleEndian.Uint32(b[20:24])
+	x.DropNext = int32(binary.LittleEndian.Uint32(b[24:28]))
+	return x, true
+}
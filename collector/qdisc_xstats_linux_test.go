@@ -0,0 +1,112 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !noqdisc
+
+package collector
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestDecodeHTBXstats(t *testing.T) {
+	b := make([]byte, htbXstatsSize)
+	binary.LittleEndian.PutUint32(b[0:4], 10)                   // lends
+	binary.LittleEndian.PutUint32(b[4:8], 20)                   // borrows
+	binary.LittleEndian.PutUint32(b[8:12], 1)                   // giants
+	binary.LittleEndian.PutUint32(b[12:16], uint32(int32(-5)))  // tokens
+	binary.LittleEndian.PutUint32(b[16:20], uint32(int32(-15))) // ctokens
+
+	got, ok := decodeHTBXstats(b)
+	if !ok {
+		t.Fatal("decodeHTBXstats: expected ok=true")
+	}
+
+	want := htbXstats{Lends: 10, Borrows: 20, Giants: 1, Tokens: -5, CTokens: -15}
+	if got != want {
+		t.Errorf("decodeHTBXstats = %+v, want %+v", got, want)
+	}
+
+	if _, ok := decodeHTBXstats(b[:htbXstatsSize-1]); ok {
+		t.Error("decodeHTBXstats: expected ok=false for short payload")
+	}
+}
+
+func TestDecodeFqCodelQdiscXstats(t *testing.T) {
+	b := make([]byte, fqCodelXstatsSize)
+	binary.LittleEndian.PutUint32(b[0:4], fqCodelXstatsQdiscType)
+	binary.LittleEndian.PutUint32(b[4:8], 1514) // maxpacket
+	binary.LittleEndian.PutUint32(b[8:12], 3)   // drop_overlimit
+	binary.LittleEndian.PutUint32(b[12:16], 42) // ecn_mark
+	binary.LittleEndian.PutUint32(b[16:20], 7)  // new_flow_count
+	binary.LittleEndian.PutUint32(b[20:24], 2)  // new_flows_len
+	binary.LittleEndian.PutUint32(b[24:28], 9)  // old_flows_len
+
+	got, ok := decodeFqCodelQdiscXstats(b)
+	if !ok {
+		t.Fatal("decodeFqCodelQdiscXstats: expected ok=true")
+	}
+
+	want := fqCodelQdiscXstats{
+		MaxPacket:     1514,
+		DropOverlimit: 3,
+		EcnMark:       42,
+		NewFlowCount:  7,
+		NewFlowsLen:   2,
+		OldFlowsLen:   9,
+	}
+	if got != want {
+		t.Errorf("decodeFqCodelQdiscXstats = %+v, want %+v", got, want)
+	}
+
+	// A class payload must not be misread as qdisc stats.
+	binary.LittleEndian.PutUint32(b[0:4], fqCodelXstatsClassType)
+	if _, ok := decodeFqCodelQdiscXstats(b); ok {
+		t.Error("decodeFqCodelQdiscXstats: expected ok=false for class-typed payload")
+	}
+}
+
+func TestDecodeFqCodelClassXstats(t *testing.T) {
+	b := make([]byte, fqCodelXstatsSize)
+	binary.LittleEndian.PutUint32(b[0:4], fqCodelXstatsClassType)
+	binary.LittleEndian.PutUint32(b[4:8], uint32(int32(-3)))   // deficit
+	binary.LittleEndian.PutUint32(b[8:12], 100)                // ldelay
+	binary.LittleEndian.PutUint32(b[12:16], 5)                 // count
+	binary.LittleEndian.PutUint32(b[16:20], 4)                 // lastcount
+	binary.LittleEndian.PutUint32(b[20:24], 1)                 // dropping
+	binary.LittleEndian.PutUint32(b[24:28], uint32(int32(-1))) // drop_next
+
+	got, ok := decodeFqCodelClassXstats(b)
+	if !ok {
+		t.Fatal("decodeFqCodelClassXstats: expected ok=true")
+	}
+
+	want := fqCodelClassXstats{
+		Deficit:   -3,
+		LDelay:    100,
+		Count:     5,
+		LastCount: 4,
+		Dropping:  1,
+		DropNext:  -1,
+	}
+	if got != want {
+		t.Errorf("decodeFqCodelClassXstats = %+v, want %+v", got, want)
+	}
+
+	// A qdisc payload must not be misread as class stats.
+	binary.LittleEndian.PutUint32(b[0:4], fqCodelXstatsQdiscType)
+	if _, ok := decodeFqCodelClassXstats(b); ok {
+		t.Error("decodeFqCodelClassXstats: expected ok=false for qdisc-typed payload")
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !notime
+
+package collector
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	ntpPacketSize  = 48
+	ntpEpochOffset = 2208988800 // seconds between the NTP epoch (1900) and the Unix epoch (1970)
+)
+
+// ntpResult holds the outcome of a single successful SNTP query.
+type ntpResult struct {
+	offset  time.Duration
+	rtt     time.Duration
+	stratum uint8
+}
+
+// queryNTP performs a single RFC 4330 SNTP v4 client/server exchange against
+// server (host or host:port, defaulting to port 123) and returns the
+// resulting clock offset, round-trip time and reported stratum. It rejects
+// responses that report an unsynchronized clock, an invalid stratum, or a
+// root distance beyond maxDistance.
+func queryNTP(server string, timeout, maxDistance time.Duration) (*ntpResult, error) {
+	addr := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		addr = net.JoinHostPort(server, "123")
+	}
+
+	conn, err := net.DialTimeout("udp", addr, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't connect to NTP server %q: %w", server, err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, fmt.Errorf("couldn't set NTP request deadline: %w", err)
+	}
+
+	var req [ntpPacketSize]byte
+	req[0] = 0x23 // LI = 0 (no warning), VN = 4, Mode = 3 (client)
+
+	t1 := time.Now()
+	if _, err := conn.Write(req[:]); err != nil {
+		return nil, fmt.Errorf("couldn't send NTP request: %w", err)
+	}
+
+	var resp [ntpPacketSize]byte
+	n, err := conn.Read(resp[:])
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read NTP response: %w", err)
+	}
+	t4 := time.Now()
+
+	return parseNTPResponse(resp[:n], server, t1, t4, maxDistance)
+}
+
+// parseNTPResponse validates and decodes a raw SNTP v4 server response,
+// combining it with the client send/receive timestamps (t1, t4) to compute
+// the clock offset and round-trip time. It's split out from queryNTP so the
+// wire-format decoding can be unit-tested without a socket.
+func parseNTPResponse(resp []byte, server string, t1, t4 time.Time, maxDistance time.Duration) (*ntpResult, error) {
+	if len(resp) != ntpPacketSize {
+		return nil, fmt.Errorf("NTP server %q returned a short response (%d of %d bytes)", server, len(resp), ntpPacketSize)
+	}
+
+	if li := resp[0] >> 6; li == 3 {
+		return nil, fmt.Errorf("NTP server %q reported an unsynchronized clock", server)
+	}
+
+	stratum := resp[1]
+	if stratum == 0 || stratum > 15 {
+		return nil, fmt.Errorf("NTP server %q reported invalid stratum %d", server, stratum)
+	}
+
+	// Root Delay is a signed 16.16 fixed-point value (RFC 5905); Root
+	// Dispersion is always non-negative.
+	rootDelay := ntpSignedShortToDuration(int32(binary.BigEndian.Uint32(resp[4:8])))
+	rootDispersion := ntpShortToDuration(binary.BigEndian.Uint32(resp[8:12]))
+	rootDistance := rootDelay/2 + rootDispersion
+	if rootDistance > maxDistance {
+		return nil, fmt.Errorf("NTP server %q root distance %s exceeds maximum of %s", server, rootDistance, maxDistance)
+	}
+
+	t2 := ntpTimestampToTime(binary.BigEndian.Uint64(resp[32:40]))
+	t3 := ntpTimestampToTime(binary.BigEndian.Uint64(resp[40:48]))
+
+	return &ntpResult{
+		offset:  (t2.Sub(t1) + t3.Sub(t4)) / 2,
+		rtt:     t4.Sub(t1) - t3.Sub(t2),
+		stratum: stratum,
+	}, nil
+}
+
+// ntpShortToDuration converts an unsigned NTP short format value (16.16 bit
+// fixed point seconds, used for Root Dispersion) into a time.Duration.
+func ntpShortToDuration(v uint32) time.Duration {
+	seconds := v >> 16
+	fraction := v & 0xffff
+	return time.Duration(seconds)*time.Second + time.Duration(fraction)*time.Second/(1<<16)
+}
+
+// ntpSignedShortToDuration converts a signed NTP short format value (16.16
+// bit fixed point seconds, used for Root Delay) into a time.Duration.
+func ntpSignedShortToDuration(v int32) time.Duration {
+	return time.Duration(v) * time.Second / (1 << 16)
+}
+
+// ntpTimestampToTime converts an NTP timestamp format value (32.32 bit fixed
+// point seconds since 1900) into a time.Time.
+func ntpTimestampToTime(v uint64) time.Time {
+	seconds := int64(v>>32) - ntpEpochOffset
+	fraction := v & 0xffffffff
+	nanos := int64(fraction) * int64(time.Second) / (1 << 32)
+	return time.Unix(seconds, nanos)
+}
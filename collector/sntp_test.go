@@ -0,0 +1,149 @@
+// Copyright 2026 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !notime
+
+package collector
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestNtpTimestampToTime(t *testing.T) {
+	// 1900-01-01 plus exactly one day, no fractional seconds.
+	const oneDay = 24 * 60 * 60
+	got := ntpTimestampToTime(uint64(oneDay) << 32)
+	want := time.Unix(oneDay-ntpEpochOffset, 0)
+	if !got.Equal(want) {
+		t.Errorf("ntpTimestampToTime = %v, want %v", got, want)
+	}
+}
+
+func TestNtpShortToDuration(t *testing.T) {
+	// 1.5 seconds: integer part 1, fractional part 0x8000 (= 1/2).
+	got := ntpShortToDuration(1<<16 | 0x8000)
+	if want := 1500 * time.Millisecond; got != want {
+		t.Errorf("ntpShortToDuration = %v, want %v", got, want)
+	}
+}
+
+func TestNtpSignedShortToDuration(t *testing.T) {
+	for _, tc := range []struct {
+		v    int32
+		want time.Duration
+	}{
+		{v: 1 << 16, want: time.Second},
+		{v: -(1 << 16), want: -time.Second},
+		{v: 0, want: 0},
+	} {
+		if got := ntpSignedShortToDuration(tc.v); got != tc.want {
+			t.Errorf("ntpSignedShortToDuration(%d) = %v, want %v", tc.v, got, tc.want)
+		}
+	}
+}
+
+// ntpTimeToTimestamp is the inverse of ntpTimestampToTime, used to build
+// synthetic server responses in tests. The fraction is rounded up so that
+// ntpTimestampToTime, which truncates, yields the original nanosecond.
+func ntpTimeToTimestamp(t time.Time) uint64 {
+	seconds := uint64(t.Unix() + ntpEpochOffset)
+	fraction := (uint64(t.Nanosecond())<<32 + uint64(time.Second) - 1) / uint64(time.Second)
+	return seconds<<32 | fraction
+}
+
+// newTestNTPResponse builds a 48-byte SNTP v4 server response with the
+// given LI, stratum, root delay/dispersion and receive/transmit
+// timestamps.
+func newTestNTPResponse(li, stratum byte, rootDelay, rootDispersion int32, t2, t3 time.Time) []byte {
+	resp := make([]byte, ntpPacketSize)
+	resp[0] = li<<6 | 0x24 // VN = 4, Mode = 4 (server)
+	resp[1] = stratum
+	binary.BigEndian.PutUint32(resp[4:8], uint32(rootDelay))
+	binary.BigEndian.PutUint32(resp[8:12], uint32(rootDispersion))
+	binary.BigEndian.PutUint64(resp[32:40], ntpTimeToTimestamp(t2))
+	binary.BigEndian.PutUint64(resp[40:48], ntpTimeToTimestamp(t3))
+	return resp
+}
+
+func TestParseNTPResponse(t *testing.T) {
+	t1 := time.Unix(1700000000, 0)
+	t2 := t1.Add(100 * time.Millisecond)
+	t3 := t2.Add(10 * time.Millisecond)
+	t4 := t1.Add(250 * time.Millisecond)
+
+	resp := newTestNTPResponse(0, 2, 0, 0, t2, t3)
+
+	got, err := parseNTPResponse(resp, "ntp.example.com", t1, t4, time.Second)
+	if err != nil {
+		t.Fatalf("parseNTPResponse: unexpected error: %v", err)
+	}
+
+	wantOffset := (t2.Sub(t1) + t3.Sub(t4)) / 2
+	wantRTT := t4.Sub(t1) - t3.Sub(t2)
+
+	if got.offset != wantOffset {
+		t.Errorf("offset = %v, want %v", got.offset, wantOffset)
+	}
+	if got.rtt != wantRTT {
+		t.Errorf("rtt = %v, want %v", got.rtt, wantRTT)
+	}
+	if got.stratum != 2 {
+		t.Errorf("stratum = %d, want 2", got.stratum)
+	}
+}
+
+func TestParseNTPResponseErrors(t *testing.T) {
+	t1 := time.Unix(1700000000, 0)
+	t4 := t1.Add(10 * time.Millisecond)
+
+	t.Run("short response", func(t *testing.T) {
+		if _, err := parseNTPResponse(make([]byte, ntpPacketSize-1), "s", t1, t4, time.Second); err == nil {
+			t.Error("expected an error for a short response")
+		}
+	})
+
+	t.Run("unsynchronized clock", func(t *testing.T) {
+		resp := newTestNTPResponse(3, 2, 0, 0, t1, t1)
+		if _, err := parseNTPResponse(resp, "s", t1, t4, time.Second); err == nil {
+			t.Error("expected an error for LI=3 (unsynchronized)")
+		}
+	})
+
+	for _, stratum := range []byte{0, 16} {
+		t.Run("invalid stratum", func(t *testing.T) {
+			resp := newTestNTPResponse(0, stratum, 0, 0, t1, t1)
+			if _, err := parseNTPResponse(resp, "s", t1, t4, time.Second); err == nil {
+				t.Errorf("expected an error for stratum %d", stratum)
+			}
+		})
+	}
+
+	t.Run("root distance exceeds max", func(t *testing.T) {
+		// Root dispersion alone of 2s exceeds a 1s max distance.
+		resp := newTestNTPResponse(0, 2, 0, 2<<16, t1, t1)
+		if _, err := parseNTPResponse(resp, "s", t1, t4, time.Second); err == nil {
+			t.Error("expected an error when root distance exceeds max-distance")
+		}
+	})
+
+	t.Run("negative root delay doesn't inflate root distance", func(t *testing.T) {
+		// A small negative root delay is valid per RFC 5905 and shouldn't
+		// be misread as a huge positive one.
+		resp := newTestNTPResponse(0, 2, -(1 << 15), 0, t1, t1)
+		if _, err := parseNTPResponse(resp, "s", t1, t4, time.Second); err != nil {
+			t.Errorf("unexpected error for small negative root delay: %v", err)
+		}
+	})
+}
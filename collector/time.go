@@ -16,15 +16,35 @@
 package collector
 
 import (
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	ntpServerFlag      = kingpin.Flag("collector.time.ntp-server", "NTP server to use for clock drift collection").Default("").String()
+	ntpTimeoutFlag     = kingpin.Flag("collector.time.ntp-timeout", "Timeout waiting for NTP server response").Default("5s").Duration()
+	ntpMaxDistanceFlag = kingpin.Flag("collector.time.ntp-max-distance", "Maximum allowed NTP root distance before a response is rejected").Default("3s").Duration()
 )
 
 type timeCollector struct {
-	desc   *prometheus.Desc
+	desc        *prometheus.Desc
+	offsetDesc  *prometheus.Desc
+	rttDesc     *prometheus.Desc
+	stratumDesc *prometheus.Desc
+
+	ntpServer      string
+	ntpTimeout     time.Duration
+	ntpMaxDistance time.Duration
+
+	mu      sync.Mutex
+	lastNTP *ntpResult
+
 	logger log.Logger
 }
 
@@ -33,7 +53,9 @@ func init() {
 }
 
 // NewTimeCollector returns a new Collector exposing the current system time in
-// seconds since epoch.
+// seconds since epoch. When collector.time.ntp-server is set, it also
+// exposes the clock offset, round-trip time and stratum reported by that
+// server.
 func NewTimeCollector(logger log.Logger) (Collector, error) {
 	return &timeCollector{
 		desc: prometheus.NewDesc(
@@ -41,7 +63,25 @@ func NewTimeCollector(logger log.Logger) (Collector, error) {
 			"System time in seconds since epoch (1970).",
 			nil, constLabels,
 		),
-		logger: logger,
+		offsetDesc: prometheus.NewDesc(
+			namespace+"_time_offset_seconds",
+			"Estimated clock offset from the configured NTP server, in seconds.",
+			nil, constLabels,
+		),
+		rttDesc: prometheus.NewDesc(
+			namespace+"_time_rtt_seconds",
+			"Round-trip time to the configured NTP server, in seconds.",
+			nil, constLabels,
+		),
+		stratumDesc: prometheus.NewDesc(
+			namespace+"_time_stratum",
+			"Stratum reported by the configured NTP server.",
+			nil, constLabels,
+		),
+		ntpServer:      *ntpServerFlag,
+		ntpTimeout:     *ntpTimeoutFlag,
+		ntpMaxDistance: *ntpMaxDistanceFlag,
+		logger:         logger,
 	}, nil
 }
 
@@ -49,5 +89,31 @@ func (c *timeCollector) Update(ch chan<- prometheus.Metric) error {
 	now := float64(time.Now().UnixNano()) / 1e9
 	level.Debug(c.logger).Log("msg", "Return time", "now", now)
 	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, now)
+
+	if c.ntpServer == "" {
+		return nil
+	}
+
+	result, err := queryNTP(c.ntpServer, c.ntpTimeout, c.ntpMaxDistance)
+	if err != nil {
+		c.mu.Lock()
+		cached := c.lastNTP
+		c.mu.Unlock()
+
+		if cached == nil {
+			return fmt.Errorf("couldn't query NTP server %q: %w", c.ntpServer, err)
+		}
+
+		level.Warn(c.logger).Log("msg", "NTP query failed, reusing last known-good result", "server", c.ntpServer, "err", err)
+		result = cached
+	} else {
+		c.mu.Lock()
+		c.lastNTP = result
+		c.mu.Unlock()
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.offsetDesc, prometheus.GaugeValue, result.offset.Seconds())
+	ch <- prometheus.MustNewConstMetric(c.rttDesc, prometheus.GaugeValue, result.rtt.Seconds())
+	ch <- prometheus.MustNewConstMetric(c.stratumDesc, prometheus.GaugeValue, float64(result.stratum))
 	return nil
 }